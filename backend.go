@@ -0,0 +1,24 @@
+package cache
+
+import "time"
+
+// Backend is the common surface implemented by every cache backend in
+// this package (MemCache, RedisCache, TieredCache), letting callers
+// swap the storage layer without changing call sites. It is named
+// Backend rather than Cache, which is already taken by the generic
+// Cache[K, V] type, to avoid a name collision between the two.
+type Backend interface {
+	// Get retrieves a value by key, returning false if it is absent or
+	// expired.
+	Get(key any) (any, bool)
+	// Set adds or overwrites key using the backend's default TTL.
+	Set(key any, value any)
+	// SetWithTTL adds or overwrites key with an explicit TTL.
+	SetWithTTL(key any, value any, ttl time.Duration)
+	// Delete removes key, if present.
+	Delete(key any)
+	// LoadAndDelete atomically fetches and removes key.
+	LoadAndDelete(key any) (any, bool)
+	// Close releases any resources held by the backend.
+	Close() error
+}
@@ -1,11 +1,15 @@
 package cache
 
 import (
+	"container/heap"
+	"container/list"
 	"context"
 	"errors"
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/wombatpiobair/cacheExercise/keyedmutex"
 )
 
 var ErrContextIsNil = errors.New("context is nil")
@@ -20,122 +24,343 @@ func (e *DuplicateKeyError) Error() string {
 	return fmt.Sprintf("key %v already exists", e.Key)
 }
 
-// VCache: a struct that implements a simple in-memory key-value cache with eviction.
-type MemCache struct {
-	timeToLiveInSeconds   int64
-	evictionCheckInterval time.Duration
-	store                 map[any]*cacheValue
+// neverExpireTTL: passed as the per-entry TTL to Add/Set to mean "this
+// entry never expires", regardless of the cache's default TTL.
+const neverExpireTTL time.Duration = -1
 
-	// one mutex for each cache
+// Cache: a generic in-memory key-value cache with eviction, configured
+// through the With* options passed to NewCache. Entries are tracked in a
+// recency-ordered list.List for LRU eviction under WithCapacity, and in
+// an expQueue min-heap so expiration doesn't require scanning the whole
+// store.
+type Cache[K comparable, V any] struct {
 	mutex *sync.RWMutex
+
+	items    map[K]*list.Element
+	lru      *list.List
+	expQueue *expQueue[K, V]
+
+	defaultTTL            time.Duration
+	evictionCheckInterval time.Duration
+	capacity              int
+
+	onEviction     func(reason EvictionReason, key K, value V)
+	onEvictionBulk func([]KeyAndValue[K, V])
+
+	loader  func(ctx context.Context, key K) (V, time.Duration, error)
+	loading map[K]*call[V]
+
+	// keyedMutex is non-nil when WithKeyedLocking is set, letting
+	// GetOrLoad and user read-modify-write sequences serialize per key
+	// via LockKey/UnlockKey without serializing the whole cache.
+	keyedMutex *keyedmutex.KeyedMutex
+
+	// timerCh wakes the eviction goroutine when an Add/Set inserts an
+	// expiration earlier than the one it is currently sleeping on.
+	timerCh chan time.Duration
 }
 
-// cacheValue: a struct that contains a value and its expiration time.
-type cacheValue struct {
-	value          any
-	expirationTime time.Time
+// cacheEntry: a struct that contains a key, its value and its expiration
+// time. It is held both by a *list.Element (for LRU ordering) and,
+// unless neverExpire is set, by the expQueue heap (for expiration
+// ordering); index tracks its position in that heap.
+type cacheEntry[K comparable, V any] struct {
+	key         K
+	value       V
+	expireAt    time.Time
+	neverExpire bool
+	index       int
 }
 
-// New: a function that creates and returns a new Cache instance.
-// Assumes duration is the same of each member of the cache
-func New(checkInterval time.Duration, timeRecordEvict time.Duration) *MemCache {
-	return &MemCache{
-		mutex:                 &sync.RWMutex{},
-		store:                 make(map[any]*cacheValue),
-		evictionCheckInterval: checkInterval,
-		timeToLiveInSeconds:   int64(timeRecordEvict.Seconds()),
+// NewCache: creates and returns a new Cache instance, configured by opts.
+func NewCache[K comparable, V any](opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		mutex:    &sync.RWMutex{},
+		items:    make(map[K]*list.Element),
+		lru:      list.New(),
+		expQueue: &expQueue[K, V]{},
+		loading:  make(map[K]*call[V]),
+		timerCh:  make(chan time.Duration, 1),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// add a key to the cache using default expire tike
-func (c *MemCache) Add(key any, value any) error {
-	err := c.AddWithExpireTime(key, value, c.timeToLiveInSeconds)
-	if err != nil {
-		return err
+// expirationFor resolves the per-entry ttl (0 = use the cache's default
+// TTL, -1 = never expire) into an expiration time. A resolved duration
+// of 0 - the default TTL for a cache built without WithTTL - also means
+// no expiration, the same as -1; otherwise an unconfigured default would
+// silently expire every entry before it could ever be read back.
+func (c *Cache[K, V]) expirationFor(ttl ...time.Duration) (time.Time, bool) {
+	d := c.defaultTTL
+	if len(ttl) > 0 && ttl[0] != 0 {
+		d = ttl[0]
 	}
-	return nil
+	if d == neverExpireTTL || d == 0 {
+		return time.Time{}, true
+	}
+	return time.Now().Add(d), false
 }
 
-// add a key to the cache with explicit expire time.
-func (c *MemCache) AddWithExpireTime(key any, value any, timeToLiveInSeconds int64) error {
-	// lock the cache for an add
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+// wakeIfNewHead pokes the eviction goroutine with the time left until
+// entry's expireAt if
+// entry just became the earliest deadline in the heap, so a freshly
+// inserted entry with a short TTL doesn't have to wait out whatever
+// deadline the goroutine was previously sleeping on.
+func (c *Cache[K, V]) wakeIfNewHead(entry *cacheEntry[K, V]) {
+	if entry.index != 0 {
+		return
+	}
+	select {
+	case c.timerCh <- time.Until(entry.expireAt):
+	default:
+	}
+}
 
-	// check for duplicate keys and add an error
-	if _, ok := c.store[key]; ok {
-		return &DuplicateKeyError{Key: key}
+// set inserts or overwrites key under the cache lock, updating LRU
+// recency and the expiration heap, and evicting the LRU tail if this is
+// a new key that would push the cache over capacity. It returns the
+// eviction event the caller should report once the lock is released, if
+// any.
+func (c *Cache[K, V]) set(key K, value V, ttl ...time.Duration) *evictionEvent[K, V] {
+	expireAt, neverExpire := c.expirationFor(ttl...)
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+		oldValue := entry.value
+		entry.value = value
+		entry.expireAt = expireAt
+		entry.neverExpire = neverExpire
+		c.lru.MoveToFront(elem)
+		c.syncHeapPosition(entry)
+		if !neverExpire {
+			c.wakeIfNewHead(entry)
+		}
+		return &evictionEvent[K, V]{reason: ReasonReplaced, key: key, value: oldValue}
+	}
+
+	var evicted *evictionEvent[K, V]
+	if c.capacity > 0 && len(c.items) >= c.capacity {
+		evicted = c.evictLRU()
+	}
+
+	entry := &cacheEntry[K, V]{
+		key:         key,
+		value:       value,
+		expireAt:    expireAt,
+		neverExpire: neverExpire,
+		index:       -1,
+	}
+	elem := c.lru.PushFront(entry)
+	c.items[key] = elem
+	if !neverExpire {
+		heap.Push(c.expQueue, entry)
+		c.wakeIfNewHead(entry)
+	}
+	return evicted
+}
+
+// syncHeapPosition fixes entry's position in the expiration heap after
+// its expireAt changed, adding or removing it as neverExpire dictates.
+func (c *Cache[K, V]) syncHeapPosition(entry *cacheEntry[K, V]) {
+	if entry.neverExpire {
+		if entry.index >= 0 {
+			heap.Remove(c.expQueue, entry.index)
+		}
+		return
+	}
+	if entry.index >= 0 {
+		heap.Fix(c.expQueue, entry.index)
+	} else {
+		heap.Push(c.expQueue, entry)
+	}
+}
+
+// evictLRU removes the least-recently-used entry, if any, returning the
+// eviction event the caller should report once the lock is released.
+func (c *Cache[K, V]) evictLRU() *evictionEvent[K, V] {
+	elem := c.lru.Back()
+	if elem == nil {
+		return nil
+	}
+	entry := elem.Value.(*cacheEntry[K, V])
+	c.removeEntry(entry)
+	return &evictionEvent[K, V]{reason: ReasonCapacityReached, key: entry.key, value: entry.value}
+}
+
+// removeEntry deletes entry from items, the LRU list and the expiration
+// heap. Callers must hold the cache lock.
+func (c *Cache[K, V]) removeEntry(entry *cacheEntry[K, V]) {
+	if elem, ok := c.items[entry.key]; ok {
+		c.lru.Remove(elem)
+		delete(c.items, entry.key)
+	}
+	if entry.index >= 0 {
+		heap.Remove(c.expQueue, entry.index)
 	}
+}
 
-	currentTime := time.Now()
-	expireTime := currentTime.Add(time.Duration(timeToLiveInSeconds) * time.Second)
-	c.store[key] = &cacheValue{
-		value:          value,
-		expirationTime: expireTime,
+// Add: adds a key to the cache, using the cache's default TTL unless an
+// explicit per-entry ttl is given (0 = default, -1 = never expire).
+// Returns a *DuplicateKeyError if the key is already present.
+func (c *Cache[K, V]) Add(key K, value V, ttl ...time.Duration) error {
+	c.mutex.Lock()
+	if _, ok := c.items[key]; ok {
+		c.mutex.Unlock()
+		return &DuplicateKeyError{Key: key}
 	}
+	evicted := c.set(key, value, ttl...)
+	c.mutex.Unlock()
 
+	c.notifyEviction(evicted)
 	return nil
 }
 
-// Get: a method that retrieves a value from the cache by its key.
-// Returns the value and a boolean indicating if the key was found.
-func (c *MemCache) Get(key any) (any, bool) {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+// Set: adds or overwrites a key in the cache, using the cache's default
+// TTL unless an explicit per-entry ttl is given (0 = default, -1 = never
+// expire).
+func (c *Cache[K, V]) Set(key K, value V, ttl ...time.Duration) {
+	c.mutex.Lock()
+	evicted := c.set(key, value, ttl...)
+	c.mutex.Unlock()
 
-	val, foundKey := c.store[key]
+	c.notifyEviction(evicted)
+}
 
-	if foundKey {
-		// make sure we have a non-expired cached item
-		if time.Now().After(val.expirationTime) {
-			return nil, false
-		}
-		return val.value, foundKey
+// Get: retrieves a value from the cache by its key, marking it as
+// recently used. Returns the value and a boolean indicating whether the
+// key was found and not expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+
+	elem, found := c.items[key]
+	if !found {
+		c.mutex.Unlock()
+		var zero V
+		return zero, false
+	}
+
+	entry := elem.Value.(*cacheEntry[K, V])
+	if !entry.neverExpire && time.Now().After(entry.expireAt) {
+		c.removeEntry(entry)
+		c.mutex.Unlock()
+		c.notifyEviction(&evictionEvent[K, V]{reason: ReasonExpired, key: entry.key, value: entry.value})
+		var zero V
+		return zero, false
 	}
-	return nil, false
+
+	c.lru.MoveToFront(elem)
+	c.mutex.Unlock()
+	return entry.value, true
 }
 
 // Delete: a method that deletes a key-value pair from the cache.
-func (c *MemCache) Delete(key any) {
+func (c *Cache[K, V]) Delete(key K) {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	delete(c.store, key)
+	elem, ok := c.items[key]
+	if !ok {
+		c.mutex.Unlock()
+		return
+	}
+	entry := elem.Value.(*cacheEntry[K, V])
+	c.removeEntry(entry)
+	c.mutex.Unlock()
+
+	c.notifyEviction(&evictionEvent[K, V]{reason: ReasonDeleted, key: entry.key, value: entry.value})
 }
 
-// Evict: a method that evicts expired key-value pairs from the cache.
-func (c *MemCache) Evict() {
+// LoadAndDelete atomically fetches and removes key under a single lock
+// acquisition, returning false if the key was absent or already expired.
+func (c *Cache[K, V]) LoadAndDelete(key K) (V, bool) {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		c.mutex.Unlock()
+		var zero V
+		return zero, false
+	}
 
-	var evictedItems []interface{}
+	entry := elem.Value.(*cacheEntry[K, V])
+	expired := !entry.neverExpire && time.Now().After(entry.expireAt)
+	c.removeEntry(entry)
+	c.mutex.Unlock()
 
-	for key, val := range c.store {
-		if time.Now().After(val.expirationTime) {
-			evictedItems = append(evictedItems, key)
-		}
+	if expired {
+		c.notifyEviction(&evictionEvent[K, V]{reason: ReasonExpired, key: entry.key, value: entry.value})
+		var zero V
+		return zero, false
+	}
+	c.notifyEviction(&evictionEvent[K, V]{reason: ReasonDeleted, key: entry.key, value: entry.value})
+	return entry.value, true
+}
+
+// Evict: a method that evicts expired key-value pairs from the cache by
+// popping the expiration heap until its head is no longer due, then
+// reports them in a single OnEvictionBulk call as well as one OnEviction
+// call per entry, so a cache configured with only WithOnEviction still
+// observes background expirations rather than just those found by Get.
+func (c *Cache[K, V]) Evict() {
+	c.mutex.Lock()
+	expired := c.evictExpiredLocked()
+	c.mutex.Unlock()
+
+	for _, kv := range expired {
+		c.notifyEviction(&evictionEvent[K, V]{reason: ReasonExpired, key: kv.Key, value: kv.Value})
 	}
+	c.notifyEvictionBulk(expired)
+}
 
-	for _, key := range evictedItems {
-		delete(c.store, key)
+// evictExpiredLocked pops entries off the expiration heap while the head
+// is due, returning the removed key/value pairs. Callers must hold the
+// cache lock.
+func (c *Cache[K, V]) evictExpiredLocked() []KeyAndValue[K, V] {
+	var expired []KeyAndValue[K, V]
+	now := time.Now()
+	for {
+		entry := c.expQueue.Peek()
+		if entry == nil || now.Before(entry.expireAt) {
+			return expired
+		}
+		heap.Pop(c.expQueue)
+		if elem, ok := c.items[entry.key]; ok {
+			c.lru.Remove(elem)
+			delete(c.items, entry.key)
+		}
+		expired = append(expired, KeyAndValue[K, V]{Key: entry.key, Value: entry.value})
 	}
 }
 
-// StartEvict: a method that starts the eviction process in a separate goroutine.
-// It stops when the context passed as an argument is done.
-func (c *MemCache) StartEvictionChecks(context context.Context) error {
-	if context == nil {
+// StartEvictionChecks: a method that starts the eviction process in a
+// separate goroutine. Instead of ticking on a fixed interval, it sleeps
+// until the expiration heap's earliest deadline, waking early whenever
+// Add/Set inserts a sooner one. It stops when the context passed as an
+// argument is done.
+func (c *Cache[K, V]) StartEvictionChecks(ctx context.Context) error {
+	if ctx == nil {
 		return ErrContextIsNil
 	}
 
-	trigger := time.NewTicker(c.evictionCheckInterval)
-	defer trigger.Stop()
-
 	go func() {
+		timer := time.NewTimer(c.nextEvictionDelay())
+		defer timer.Stop()
+
 		for {
 			select {
-			case <-trigger.C:
+			case <-timer.C:
 				c.Evict()
-			case <-context.Done():
+				timer.Reset(c.nextEvictionDelay())
+			case d := <-c.timerCh:
+				if !timer.Stop() {
+					<-timer.C
+				}
+				if d <= 0 {
+					c.Evict()
+					d = c.nextEvictionDelay()
+				}
+				timer.Reset(d)
+			case <-ctx.Done():
 				return
 			}
 		}
@@ -143,3 +368,34 @@ func (c *MemCache) StartEvictionChecks(context context.Context) error {
 
 	return nil
 }
+
+// noScheduledEviction is returned by nextEvictionDelay when there is
+// nothing to poll for: the expiration heap is empty and no fallback
+// evictionCheckInterval is configured. It is effectively "sleep until
+// woken", since timer.Reset with a zero or negative duration fires
+// immediately and would otherwise busy-spin the eviction goroutine.
+const noScheduledEviction time.Duration = 1<<63 - 1
+
+// nextEvictionDelay returns how long StartEvictionChecks should sleep
+// before its next Evict pass: until the expiration heap's earliest
+// deadline, or evictionCheckInterval as a fallback poll when the heap is
+// empty. If the heap is empty and no evictionCheckInterval is
+// configured, there is nothing to poll for, so it returns
+// noScheduledEviction rather than 0 (which would fire immediately,
+// evict nothing, and spin the goroutine at 100% CPU).
+func (c *Cache[K, V]) nextEvictionDelay() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry := c.expQueue.Peek()
+	if entry == nil {
+		if c.evictionCheckInterval <= 0 {
+			return noScheduledEviction
+		}
+		return c.evictionCheckInterval
+	}
+	if d := time.Until(entry.expireAt); d > 0 {
+		return d
+	}
+	return 0
+}
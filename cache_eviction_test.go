@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheCapacityEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewCache[string, int](WithCapacity[string, int](2))
+
+	assert.NoError(t, c.Add("a", 1, neverExpireTTL))
+	assert.NoError(t, c.Add("b", 2, neverExpireTTL))
+
+	// touch "a" so "b" becomes the least-recently-used entry.
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	assert.NoError(t, c.Add("c", 3, neverExpireTTL))
+
+	_, ok = c.Get("b")
+	assert.False(t, ok, "least-recently-used entry should have been evicted")
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	v, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+}
+
+func TestCacheCapacityEvictionReportsReason(t *testing.T) {
+	var reason EvictionReason
+	var key string
+	c := NewCache[string, int](
+		WithCapacity[string, int](1),
+		WithOnEviction[string, int](func(r EvictionReason, k string, v int) {
+			reason = r
+			key = k
+		}),
+	)
+
+	assert.NoError(t, c.Add("a", 1, neverExpireTTL))
+	assert.NoError(t, c.Add("b", 2, neverExpireTTL))
+
+	assert.Equal(t, ReasonCapacityReached, reason)
+	assert.Equal(t, "a", key)
+}
+
+func TestCacheEvictPopsExpiredHeapHead(t *testing.T) {
+	c := NewCache[string, int]()
+
+	assert.NoError(t, c.Add("soon", 1, 10*time.Millisecond))
+	assert.NoError(t, c.Add("later", 2, time.Hour))
+
+	time.Sleep(30 * time.Millisecond)
+	c.Evict()
+
+	_, ok := c.Get("soon")
+	assert.False(t, ok, "expired entry should have been evicted")
+
+	v, ok := c.Get("later")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestNextEvictionDelayDoesNotBusySpinOnEmptyHeap(t *testing.T) {
+	// No WithEvictionInterval and nothing in the expiration heap: there
+	// is nothing to poll for, so nextEvictionDelay must not return 0
+	// (StartEvictionChecks would Reset(0), Evict a no-op, and repeat
+	// forever, busy-spinning the goroutine).
+	c := NewCache[string, int]()
+	assert.Equal(t, noScheduledEviction, c.nextEvictionDelay())
+}
+
+func TestCacheEvictReportsOnEvictionForExpiredEntries(t *testing.T) {
+	var reasons []EvictionReason
+	var keys []string
+	c := NewCache[string, int](
+		WithOnEviction[string, int](func(r EvictionReason, k string, v int) {
+			reasons = append(reasons, r)
+			keys = append(keys, k)
+		}),
+	)
+
+	assert.NoError(t, c.Add("soon", 1, 10*time.Millisecond))
+	time.Sleep(30 * time.Millisecond)
+	c.Evict()
+
+	assert.Equal(t, []string{"soon"}, keys, "background sweep should report expirations through OnEviction, not just OnEvictionBulk")
+	assert.Equal(t, []EvictionReason{ReasonExpired}, reasons)
+}
+
+func TestCacheStartEvictionChecksWakesOnEarlierDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A long fallback interval: if the timerCh wake path didn't exist,
+	// the goroutine would still be asleep for this long when we check.
+	c := NewCache[string, int](WithEvictionInterval[string, int](time.Hour))
+	assert.NoError(t, c.StartEvictionChecks(ctx))
+
+	// Insert an entry with a deadline much sooner than the fallback
+	// interval; StartEvictionChecks should wake up and evict it well
+	// before the hour-long poll would have fired. Checking the raw
+	// store (rather than Get, which lazily expires entries itself)
+	// confirms the background goroutine did the evicting.
+	assert.NoError(t, c.Add("soon", 1, 20*time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		c.mutex.RLock()
+		_, present := c.items["soon"]
+		c.mutex.RUnlock()
+		return !present
+	}, time.Second, 10*time.Millisecond, "entry should be evicted promptly via the timerCh wake path")
+}
@@ -0,0 +1,69 @@
+package cache
+
+// EvictionReason describes why an entry left the cache, passed to the
+// callback registered via WithOnEviction.
+type EvictionReason int
+
+const (
+	// ReasonDeleted: the entry was removed by an explicit Delete or
+	// LoadAndDelete call.
+	ReasonDeleted EvictionReason = iota
+	// ReasonExpired: the entry's TTL elapsed.
+	ReasonExpired
+	// ReasonCapacityReached: the entry was the least-recently-used one,
+	// evicted to make room for a new key under WithCapacity.
+	ReasonCapacityReached
+	// ReasonReplaced: the entry was overwritten by a Set call for the
+	// same key.
+	ReasonReplaced
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonDeleted:
+		return "deleted"
+	case ReasonExpired:
+		return "expired"
+	case ReasonCapacityReached:
+		return "capacity_reached"
+	case ReasonReplaced:
+		return "replaced"
+	default:
+		return "unknown"
+	}
+}
+
+// KeyAndValue pairs a key and value evicted together, passed to the
+// callback registered via WithOnEvictionBulk.
+type KeyAndValue[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// evictionEvent describes a single entry leaving the cache, queued up
+// while the cache lock is held so the OnEviction callback can run after
+// it is released.
+type evictionEvent[K comparable, V any] struct {
+	reason EvictionReason
+	key    K
+	value  V
+}
+
+// notifyEviction invokes the OnEviction callback, if any. Callers must
+// not hold the cache lock.
+func (c *Cache[K, V]) notifyEviction(event *evictionEvent[K, V]) {
+	if event == nil || c.onEviction == nil {
+		return
+	}
+	c.onEviction(event.reason, event.key, event.value)
+}
+
+// notifyEvictionBulk invokes the OnEvictionBulk callback, if any, with
+// the entries removed together by a single sweep. Callers must not hold
+// the cache lock.
+func (c *Cache[K, V]) notifyEvictionBulk(entries []KeyAndValue[K, V]) {
+	if len(entries) == 0 || c.onEvictionBulk == nil {
+		return
+	}
+	c.onEvictionBulk(entries)
+}
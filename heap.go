@@ -0,0 +1,43 @@
+package cache
+
+// expQueue: a container/heap of *cacheEntry ordered by expireAt, used to
+// find the next entry due to expire without scanning the whole cache.
+// Entries with neverExpire set are never pushed onto the queue.
+type expQueue[K comparable, V any] []*cacheEntry[K, V]
+
+func (q expQueue[K, V]) Len() int { return len(q) }
+
+func (q expQueue[K, V]) Less(i, j int) bool {
+	return q[i].expireAt.Before(q[j].expireAt)
+}
+
+func (q expQueue[K, V]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *expQueue[K, V]) Push(x any) {
+	entry := x.(*cacheEntry[K, V])
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *expQueue[K, V]) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*q = old[:n-1]
+	return entry
+}
+
+// Peek returns the entry with the earliest expiration, or nil if the
+// queue is empty.
+func (q expQueue[K, V]) Peek() *cacheEntry[K, V] {
+	if len(q) == 0 {
+		return nil
+	}
+	return q[0]
+}
@@ -0,0 +1,42 @@
+package cache
+
+import "github.com/wombatpiobair/cacheExercise/keyedmutex"
+
+// WithKeyedLocking enables per-key locking via LockKey/TryLockKey/
+// UnlockKey, and makes GetOrLoad hold the per-key lock around the
+// loader call so it serializes correctly with any user-level
+// read-modify-write sequence that locks the same key.
+func WithKeyedLocking[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.keyedMutex = keyedmutex.New()
+	}
+}
+
+// LockKey acquires the per-key lock for key, blocking until available.
+// Only meaningful on a cache built with WithKeyedLocking; it is a no-op
+// otherwise.
+func (c *Cache[K, V]) LockKey(key K) {
+	if c.keyedMutex == nil {
+		return
+	}
+	c.keyedMutex.Lock(key)
+}
+
+// TryLockKey acquires the per-key lock for key without blocking,
+// reporting whether it succeeded. Always succeeds on a cache built
+// without WithKeyedLocking.
+func (c *Cache[K, V]) TryLockKey(key K) bool {
+	if c.keyedMutex == nil {
+		return true
+	}
+	return c.keyedMutex.TryLock(key)
+}
+
+// UnlockKey releases the per-key lock for key. Only meaningful on a
+// cache built with WithKeyedLocking; it is a no-op otherwise.
+func (c *Cache[K, V]) UnlockKey(key K) {
+	if c.keyedMutex == nil {
+		return
+	}
+	c.keyedMutex.Unlock(key)
+}
@@ -0,0 +1,74 @@
+// Package keyedmutex provides a mutex that locks per key instead of
+// globally, so unrelated keys never contend with each other.
+package keyedmutex
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// KeyedMutex locks per key rather than globally. The zero value is not
+// usable; construct one with New.
+type KeyedMutex struct {
+	mu sync.Mutex
+	// waiters[key] being present means key is locked. An empty list
+	// means the lock is held with nobody waiting; a non-empty list
+	// holds one channel per waiter, in arrival order.
+	waiters map[any]*list.List
+}
+
+// New returns a ready-to-use KeyedMutex.
+func New() *KeyedMutex {
+	return &KeyedMutex{waiters: make(map[any]*list.List)}
+}
+
+// Lock acquires the lock for key, blocking until it is available.
+func (k *KeyedMutex) Lock(key any) {
+	k.mu.Lock()
+	waitlist, held := k.waiters[key]
+	if !held {
+		k.waiters[key] = list.New()
+		k.mu.Unlock()
+		return
+	}
+
+	ch := make(chan struct{})
+	waitlist.PushBack(ch)
+	k.mu.Unlock()
+	<-ch
+}
+
+// TryLock acquires the lock for key without blocking, reporting whether
+// it succeeded.
+func (k *KeyedMutex) TryLock(key any) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, held := k.waiters[key]; held {
+		return false
+	}
+	k.waiters[key] = list.New()
+	return true
+}
+
+// Unlock releases the lock for key, waking the longest-waiting blocked
+// Lock call, if any. Unlock of a key that is not currently locked panics
+// to surface the bug immediately rather than silently corrupting state.
+func (k *KeyedMutex) Unlock(key any) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	waitlist, held := k.waiters[key]
+	if !held {
+		panic(fmt.Sprintf("keyedmutex: unlock of unheld key %v", key))
+	}
+
+	front := waitlist.Front()
+	if front == nil {
+		delete(k.waiters, key)
+		return
+	}
+	waitlist.Remove(front)
+	close(front.Value.(chan struct{}))
+}
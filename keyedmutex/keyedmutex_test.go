@@ -0,0 +1,115 @@
+package keyedmutex
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockExcludesConcurrentAccessToSameKey(t *testing.T) {
+	km := New()
+
+	var active int32
+	var maxActive int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			km.Lock("key")
+			defer km.Unlock("key")
+
+			n := atomic.AddInt32(&active, 1)
+			for {
+				max := atomic.LoadInt32(&maxActive)
+				if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(1), maxActive, "at most one goroutine should hold the lock for a given key at a time")
+}
+
+func TestLockDoesNotSerializeDifferentKeys(t *testing.T) {
+	km := New()
+	km.Lock("a")
+	defer km.Unlock("a")
+
+	done := make(chan struct{})
+	go func() {
+		km.Lock("b")
+		km.Unlock("b")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("locking an unrelated key should not block on key \"a\"'s lock")
+	}
+}
+
+func TestLockWakesInArrivalOrder(t *testing.T) {
+	km := New()
+	km.Lock("key")
+
+	const waiters = 3
+	order := make(chan int, waiters)
+
+	for i := 0; i < waiters; i++ {
+		i := i
+		go func() {
+			km.Lock("key")
+			order <- i
+			km.Unlock("key")
+		}()
+		// Wait for goroutine i to have actually enqueued itself before
+		// starting the next one, so arrival order is deterministic
+		// instead of relying on sleeps to win a scheduling race.
+		waitForWaitlistLen(t, km, "key", i+1)
+	}
+
+	km.Unlock("key")
+
+	for i := 0; i < waiters; i++ {
+		assert.Equal(t, i, <-order, "waiters should be woken in FIFO arrival order")
+	}
+}
+
+// waitForWaitlistLen polls km's internal waitlist for key until it has
+// exactly n queued waiters.
+func waitForWaitlistLen(t *testing.T, km *KeyedMutex, key any, n int) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		km.mu.Lock()
+		defer km.mu.Unlock()
+		waitlist, ok := km.waiters[key]
+		return ok && waitlist.Len() == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestTryLockFailsWhileHeld(t *testing.T) {
+	km := New()
+
+	assert.True(t, km.TryLock("key"))
+	assert.False(t, km.TryLock("key"), "TryLock should fail while the key is already locked")
+
+	km.Unlock("key")
+	assert.True(t, km.TryLock("key"), "TryLock should succeed once the key is released")
+}
+
+func TestUnlockOfUnheldKeyPanics(t *testing.T) {
+	km := New()
+	assert.PanicsWithValue(t, "keyedmutex: unlock of unheld key key", func() {
+		km.Unlock("key")
+	})
+}
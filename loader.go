@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoLoader is returned by Load when the cache was not constructed
+// with WithLoader.
+var ErrNoLoader = errors.New("cache: no loader configured")
+
+// call represents an in-flight load for a single key: the first caller
+// to miss runs the loader and populates value/ttl/err; every other
+// caller for the same key waits on wg and shares the result.
+type call[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	ttl   time.Duration
+	err   error
+}
+
+// Load retrieves key, running the loader configured via WithLoader on a
+// miss. Concurrent misses for the same key are coalesced so the loader
+// runs exactly once; see GetOrLoad.
+func (c *Cache[K, V]) Load(ctx context.Context, key K) (V, error) {
+	if c.loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+	return c.GetOrLoad(ctx, key, func(ctx context.Context) (V, time.Duration, error) {
+		return c.loader(ctx, key)
+	})
+}
+
+// GetOrLoad retrieves key, running loader on a miss and caching the
+// result with the returned TTL. Concurrent misses for the same key are
+// coalesced: the first caller runs loader while every other caller waits
+// on its result, so loader runs exactly once no matter how many
+// goroutines request the key at the same time. Honors ctx.Done() for
+// waiters, so a cancelled caller is not blocked by a slow loader.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader func(ctx context.Context) (V, time.Duration, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	c.mutex.Lock()
+	if existing, ok := c.loading[key]; ok {
+		c.mutex.Unlock()
+		return c.waitForCall(ctx, existing)
+	}
+
+	cl := &call[V]{}
+	cl.wg.Add(1)
+	c.loading[key] = cl
+	c.mutex.Unlock()
+
+	c.LockKey(key)
+	value, ttl, err := loader(ctx)
+	c.UnlockKey(key)
+	cl.value, cl.ttl, cl.err = value, ttl, err
+	if err == nil {
+		c.Set(key, value, ttl)
+	}
+
+	c.mutex.Lock()
+	delete(c.loading, key)
+	c.mutex.Unlock()
+	cl.wg.Done()
+
+	return value, err
+}
+
+// waitForCall blocks until cl's loader finishes or ctx is done, whichever
+// comes first.
+func (c *Cache[K, V]) waitForCall(ctx context.Context, cl *call[V]) (V, error) {
+	done := make(chan struct{})
+	go func() {
+		cl.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return cl.value, cl.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrLoadCoalescesConcurrentMisses(t *testing.T) {
+	c := NewCache[string, int]()
+
+	var calls int32
+	loader := func(ctx context.Context) (int, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return 42, time.Hour, nil
+	}
+
+	const goroutines = 10
+	results := make(chan int, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			v, err := c.GetOrLoad(context.Background(), "key", loader)
+			assert.NoError(t, err)
+			results <- v
+		}()
+	}
+
+	for i := 0; i < goroutines; i++ {
+		assert.Equal(t, 42, <-results)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "loader should run exactly once for concurrent misses")
+
+	v, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+}
+
+func TestGetOrLoadReturnsLoaderError(t *testing.T) {
+	c := NewCache[string, int]()
+	wantErr := assert.AnError
+
+	_, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context) (int, time.Duration, error) {
+		return 0, 0, wantErr
+	})
+	assert.ErrorIs(t, err, wantErr)
+
+	_, ok := c.Get("key")
+	assert.False(t, ok, "a failed load should not populate the cache")
+}
+
+func TestGetOrLoadWaiterHonorsContextCancellation(t *testing.T) {
+	c := NewCache[string, int]()
+
+	started := make(chan struct{})
+	loader := func(ctx context.Context) (int, time.Duration, error) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		return 1, time.Hour, nil
+	}
+	go c.GetOrLoad(context.Background(), "key", loader)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetOrLoad(ctx, "key", loader)
+	elapsed := time.Since(start)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, elapsed, 150*time.Millisecond, "cancelled waiter should not block for the full loader duration")
+}
+
+func TestLoadUsesConfiguredLoader(t *testing.T) {
+	c := NewCache[string, int](
+		WithLoader[string, int](func(ctx context.Context, key string) (int, time.Duration, error) {
+			return len(key), time.Hour, nil
+		}),
+	)
+
+	v, err := c.Load(context.Background(), "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, v)
+}
+
+func TestLoadWithoutLoaderReturnsErrNoLoader(t *testing.T) {
+	c := NewCache[string, int]()
+	_, err := c.Load(context.Background(), "key")
+	assert.ErrorIs(t, err, ErrNoLoader)
+}
+
+func TestMemCacheGetOrLoadCoalescesConcurrentMisses(t *testing.T) {
+	m := New(time.Minute, time.Hour)
+
+	var calls int32
+	loader := func(ctx context.Context) (any, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return 42, time.Hour, nil
+	}
+
+	const goroutines = 10
+	results := make(chan any, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			v, err := m.GetOrLoad(context.Background(), "key", loader)
+			assert.NoError(t, err)
+			results <- v
+		}()
+	}
+
+	for i := 0; i < goroutines; i++ {
+		assert.Equal(t, 42, <-results)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "loader should run exactly once for concurrent misses")
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// MemCache: a thin wrapper around Cache[any, any] kept for back-compat
+// with callers that have not moved to the generic Cache/NewCache API.
+// It implements Backend.
+type MemCache struct {
+	c *Cache[any, any]
+}
+
+var _ Backend = (*MemCache)(nil)
+
+// New: a function that creates and returns a new MemCache instance.
+// timeRecordEvict is the default TTL for entries added without an
+// explicit one (see AddWithExpireTime and SetWithTTL).
+func New(checkInterval time.Duration, timeRecordEvict time.Duration) *MemCache {
+	return &MemCache{
+		c: NewCache[any, any](
+			WithEvictionInterval[any, any](checkInterval),
+			WithTTL[any, any](timeRecordEvict),
+		),
+	}
+}
+
+// add a key to the cache using default expire tike
+func (m *MemCache) Add(key any, value any) error {
+	return m.c.Add(key, value)
+}
+
+// add a key to the cache with explicit expire time.
+func (m *MemCache) AddWithExpireTime(key any, value any, timeToLiveInSeconds int64) error {
+	return m.c.Add(key, value, time.Duration(timeToLiveInSeconds)*time.Second)
+}
+
+// Get: a method that retrieves a value from the cache by its key.
+// Returns the value and a boolean indicating if the key was found.
+func (m *MemCache) Get(key any) (any, bool) {
+	return m.c.Get(key)
+}
+
+// Set: adds or overwrites a key in the cache using the default TTL.
+func (m *MemCache) Set(key any, value any) {
+	m.c.Set(key, value)
+}
+
+// SetWithTTL: adds or overwrites a key in the cache with an explicit TTL.
+func (m *MemCache) SetWithTTL(key any, value any, ttl time.Duration) {
+	m.c.Set(key, value, ttl)
+}
+
+// LoadAndDelete: atomically fetches and removes a key, returning false
+// if it was absent or already expired.
+func (m *MemCache) LoadAndDelete(key any) (any, bool) {
+	return m.c.LoadAndDelete(key)
+}
+
+// GetOrLoad retrieves key, running loader on a miss and caching the
+// result with the returned TTL. Concurrent misses for the same key are
+// coalesced so loader runs exactly once no matter how many goroutines
+// request the key at the same time; see Cache.GetOrLoad.
+func (m *MemCache) GetOrLoad(ctx context.Context, key any, loader func(ctx context.Context) (any, time.Duration, error)) (any, error) {
+	return m.c.GetOrLoad(ctx, key, loader)
+}
+
+// Delete: a method that deletes a key-value pair from the cache.
+func (m *MemCache) Delete(key any) {
+	m.c.Delete(key)
+}
+
+// Close: MemCache holds no external resources; Close always returns nil.
+// It exists to satisfy Backend alongside backends that do (RedisCache,
+// TieredCache).
+func (m *MemCache) Close() error {
+	return nil
+}
+
+// Evict: a method that evicts expired key-value pairs from the cache.
+func (m *MemCache) Evict() {
+	m.c.Evict()
+}
+
+// StartEvictionChecks: a method that starts the eviction process in a separate goroutine.
+// It stops when the context passed as an argument is done.
+func (m *MemCache) StartEvictionChecks(ctx context.Context) error {
+	return m.c.StartEvictionChecks(ctx)
+}
+
+// Save gob-encodes every entry in the cache to w, so it can be restored
+// with Load. Concrete value types must be registered with Registrable
+// beforehand.
+func (m *MemCache) Save(w io.Writer) error {
+	return m.c.SaveSnapshot(w)
+}
+
+// SaveFile writes a snapshot to path, creating or truncating it; see
+// Save.
+func (m *MemCache) SaveFile(path string) error {
+	return m.c.SaveSnapshotFile(path)
+}
+
+// Load reads entries written by Save from r and merges them into the
+// cache, skipping any that already expired and reporting collisions
+// with existing keys as a joined DuplicateKeyError.
+func (m *MemCache) Load(r io.Reader) error {
+	return m.c.LoadSnapshot(r)
+}
+
+// LoadFile reads a snapshot written by SaveFile from path and merges it
+// into the cache; see Load.
+func (m *MemCache) LoadFile(path string) error {
+	return m.c.LoadSnapshotFile(path)
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Option: a functional option that configures a Cache at construction
+// time via NewCache.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithTTL sets the cache's default time-to-live, used whenever Add/Set
+// is called without an explicit per-entry ttl.
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.defaultTTL = ttl
+	}
+}
+
+// WithEvictionInterval sets the fallback poll interval StartEvictionChecks
+// uses while the expiration heap is empty; once entries with a TTL are
+// added, it sleeps until their actual deadlines instead of polling.
+func WithEvictionInterval[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.evictionCheckInterval = interval
+	}
+}
+
+// WithCapacity bounds the number of entries the cache holds; inserting a
+// new key past capacity evicts the least-recently-used entry.
+func WithCapacity[K comparable, V any](n int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.capacity = n
+	}
+}
+
+// WithOnEviction registers a callback invoked whenever a single entry
+// leaves the cache, with the reason it left. It runs outside the cache
+// lock, so it may safely call back into the cache.
+func WithOnEviction[K comparable, V any](f func(reason EvictionReason, key K, value V)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEviction = f
+	}
+}
+
+// WithOnEvictionBulk registers a callback invoked once per Evict sweep
+// with every expired entry it removed. It runs outside the cache lock,
+// so it may safely call back into the cache.
+func WithOnEvictionBulk[K comparable, V any](f func([]KeyAndValue[K, V])) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvictionBulk = f
+	}
+}
+
+// WithLoader registers the loader used by Load to populate the cache on
+// a miss.
+func WithLoader[K comparable, V any](loader func(ctx context.Context, key K) (V, time.Duration, error)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.loader = loader
+	}
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// Registrable registers a concrete value type with encoding/gob so it
+// can round-trip through the any-typed Key/Value fields that
+// SaveSnapshot/LoadSnapshot encode. Call it once per concrete type
+// stored in the cache, before the first Save or Load, e.g.
+// cache.Registrable(MyStruct{}).
+func Registrable(value any) {
+	gob.Register(value)
+}
+
+// persistedEntry is the gob-encoded form of one cache entry written by
+// SaveSnapshot.
+type persistedEntry[K comparable, V any] struct {
+	Key                K
+	Value              V
+	ExpirationUnixNano int64 // 0 means the entry never expires
+}
+
+// SaveSnapshot gob-encodes every entry in the cache to w. Where K or V
+// is an interface type (as in MemCache), the concrete types stored
+// behind it must be registered with Registrable beforehand.
+func (c *Cache[K, V]) SaveSnapshot(w io.Writer) error {
+	c.mutex.RLock()
+	entries := make([]persistedEntry[K, V], 0, len(c.items))
+	for _, elem := range c.items {
+		entry := elem.Value.(*cacheEntry[K, V])
+		var expirationUnixNano int64
+		if !entry.neverExpire {
+			expirationUnixNano = entry.expireAt.UnixNano()
+		}
+		entries = append(entries, persistedEntry[K, V]{
+			Key:                entry.key,
+			Value:              entry.value,
+			ExpirationUnixNano: expirationUnixNano,
+		})
+	}
+	c.mutex.RUnlock()
+
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+// SaveSnapshotFile writes a snapshot to path, creating or truncating it.
+func (c *Cache[K, V]) SaveSnapshotFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.SaveSnapshot(f)
+}
+
+// LoadSnapshot reads entries written by SaveSnapshot from r and merges
+// them into the cache, skipping any that already expired. It never
+// overwrites a key already present in the cache; collisions are
+// aggregated as DuplicateKeyErrors and returned together via
+// errors.Join.
+func (c *Cache[K, V]) LoadSnapshot(r io.Reader) error {
+	var entries []persistedEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var errs []error
+	for _, entry := range entries {
+		ttl := neverExpireTTL
+		if entry.ExpirationUnixNano != 0 {
+			expireAt := time.Unix(0, entry.ExpirationUnixNano)
+			if now.After(expireAt) {
+				continue
+			}
+			ttl = time.Until(expireAt)
+		}
+		if err := c.Add(entry.Key, entry.Value, ttl); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadSnapshotFile reads a snapshot written by SaveSnapshotFile from path
+// and merges it into the cache; see LoadSnapshot.
+func (c *Cache[K, V]) LoadSnapshotFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.LoadSnapshot(f)
+}
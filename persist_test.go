@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveSnapshotLoadSnapshotRoundTrip(t *testing.T) {
+	src := NewCache[string, int]()
+	assert.NoError(t, src.Add("a", 1, neverExpireTTL))
+	assert.NoError(t, src.Add("b", 2, time.Hour))
+
+	var buf bytes.Buffer
+	assert.NoError(t, src.SaveSnapshot(&buf))
+
+	dst := NewCache[string, int]()
+	assert.NoError(t, dst.LoadSnapshot(&buf))
+
+	v, ok := dst.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	v, ok = dst.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestSaveSnapshotFileLoadSnapshotFileRoundTrip(t *testing.T) {
+	src := NewCache[string, int]()
+	assert.NoError(t, src.Add("a", 1, neverExpireTTL))
+
+	path := t.TempDir() + "/snapshot.gob"
+	assert.NoError(t, src.SaveSnapshotFile(path))
+
+	dst := NewCache[string, int]()
+	assert.NoError(t, dst.LoadSnapshotFile(path))
+
+	v, ok := dst.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestLoadSnapshotSkipsAlreadyExpiredEntries(t *testing.T) {
+	src := NewCache[string, int]()
+	assert.NoError(t, src.Add("stale", 1, 10*time.Millisecond))
+	time.Sleep(30 * time.Millisecond)
+
+	// SaveSnapshot dumps raw state, including entries past their
+	// expiration that just haven't been swept yet.
+	var buf bytes.Buffer
+	assert.NoError(t, src.SaveSnapshot(&buf))
+
+	dst := NewCache[string, int]()
+	assert.NoError(t, dst.LoadSnapshot(&buf))
+
+	_, ok := dst.Get("stale")
+	assert.False(t, ok, "an already-expired snapshot entry should not be loaded")
+}
+
+func TestLoadSnapshotAggregatesDuplicateKeyErrors(t *testing.T) {
+	src := NewCache[string, int]()
+	assert.NoError(t, src.Add("a", 1, neverExpireTTL))
+	assert.NoError(t, src.Add("b", 2, neverExpireTTL))
+
+	var buf bytes.Buffer
+	assert.NoError(t, src.SaveSnapshot(&buf))
+
+	dst := NewCache[string, int]()
+	assert.NoError(t, dst.Add("a", 99, neverExpireTTL))
+
+	err := dst.LoadSnapshot(&buf)
+	assert.Error(t, err)
+
+	var dupErr *DuplicateKeyError
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, "a", dupErr.Key)
+
+	// the colliding key keeps its original value rather than being
+	// overwritten by the snapshot.
+	v, ok := dst.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 99, v)
+
+	// non-colliding entries still merge in.
+	v, ok = dst.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestMemCacheSaveLoadRoundTrip(t *testing.T) {
+	Registrable("")
+	Registrable(0)
+
+	src := New(time.Minute, time.Hour)
+	assert.NoError(t, src.Add("a", "hello"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, src.Save(&buf))
+
+	dst := New(time.Minute, time.Hour)
+	assert.NoError(t, dst.Load(&buf))
+
+	v, ok := dst.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "hello", v)
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a thin Backend adapter over a go-redis client. Values
+// are gob-encoded, so callers storing concrete types behind an any must
+// gob.Register them beforehand, same as SaveFile/LoadFile.
+type RedisCache struct {
+	client     *redis.Client
+	defaultTTL time.Duration
+}
+
+var _ Backend = (*RedisCache)(nil)
+
+// NewRedisCache wraps client as a Backend, using defaultTTL for Set
+// calls that don't specify one.
+func NewRedisCache(client *redis.Client, defaultTTL time.Duration) *RedisCache {
+	return &RedisCache{client: client, defaultTTL: defaultTTL}
+}
+
+// Get retrieves a value by key, returning false if it is absent,
+// expired, or fails to decode.
+func (r *RedisCache) Get(key any) (any, bool) {
+	data, err := r.client.Get(context.Background(), fmt.Sprint(key)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var value any
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set adds or overwrites key using the backend's default TTL.
+func (r *RedisCache) Set(key any, value any) {
+	r.SetWithTTL(key, value, r.defaultTTL)
+}
+
+// SetWithTTL adds or overwrites key with an explicit TTL. A ttl of
+// neverExpireTTL is translated to redis's own "no expiration" (ttl 0).
+func (r *RedisCache) SetWithTTL(key any, value any, ttl time.Duration) {
+	if ttl == neverExpireTTL {
+		ttl = 0
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+		return
+	}
+	r.client.Set(context.Background(), fmt.Sprint(key), buf.Bytes(), ttl)
+}
+
+// Delete removes key, if present.
+func (r *RedisCache) Delete(key any) {
+	r.client.Del(context.Background(), fmt.Sprint(key))
+}
+
+// LoadAndDelete fetches and removes key. Redis has no atomic
+// get-and-delete for arbitrary values, so this is a Get followed by a
+// Del rather than a single round trip.
+func (r *RedisCache) LoadAndDelete(key any) (any, bool) {
+	value, ok := r.Get(key)
+	if !ok {
+		return nil, false
+	}
+	r.Delete(key)
+	return value, true
+}
+
+// Close closes the underlying redis client.
+func (r *RedisCache) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"errors"
+	"time"
+)
+
+// WriteMode controls how TieredCache propagates writes to its L2
+// backend.
+type WriteMode int
+
+const (
+	// WriteThrough writes to L2 synchronously, as part of Set/SetWithTTL.
+	WriteThrough WriteMode = iota
+	// WriteBack writes to L1 synchronously and to L2 in a background
+	// goroutine, trading a small window of L1/L2 inconsistency for
+	// lower write latency.
+	WriteBack
+)
+
+// TieredCache composes two Backends into an L1/L2 hierarchy: reads hit
+// L1 first and populate it on an L2 hit; writes always go to L1 and are
+// propagated to L2 according to WriteMode.
+type TieredCache struct {
+	l1        Backend
+	l2        Backend
+	writeMode WriteMode
+}
+
+var _ Backend = (*TieredCache)(nil)
+
+// NewTieredCache composes l1 and l2 into a TieredCache, propagating
+// writes to l2 according to writeMode.
+func NewTieredCache(l1, l2 Backend, writeMode WriteMode) *TieredCache {
+	return &TieredCache{l1: l1, l2: l2, writeMode: writeMode}
+}
+
+// Get checks L1 first, falling back to L2 on a miss and populating L1
+// with whatever L2 returns.
+func (t *TieredCache) Get(key any) (any, bool) {
+	if value, ok := t.l1.Get(key); ok {
+		return value, true
+	}
+
+	value, ok := t.l2.Get(key)
+	if !ok {
+		return nil, false
+	}
+	t.l1.Set(key, value)
+	return value, true
+}
+
+// Set adds or overwrites key in L1, propagating to L2 per WriteMode.
+func (t *TieredCache) Set(key any, value any) {
+	t.l1.Set(key, value)
+	t.propagate(func(l2 Backend) { l2.Set(key, value) })
+}
+
+// SetWithTTL adds or overwrites key in L1 with an explicit TTL,
+// propagating to L2 per WriteMode.
+func (t *TieredCache) SetWithTTL(key any, value any, ttl time.Duration) {
+	t.l1.SetWithTTL(key, value, ttl)
+	t.propagate(func(l2 Backend) { l2.SetWithTTL(key, value, ttl) })
+}
+
+// propagate runs write against L2, synchronously under WriteThrough or
+// in a background goroutine under WriteBack.
+func (t *TieredCache) propagate(write func(l2 Backend)) {
+	if t.writeMode == WriteBack {
+		go write(t.l2)
+		return
+	}
+	write(t.l2)
+}
+
+// Delete removes key from both tiers. Deletes are always synchronous,
+// regardless of WriteMode, since a stale L2 entry would otherwise
+// resurrect a deleted key on the next L1 miss.
+func (t *TieredCache) Delete(key any) {
+	t.l1.Delete(key)
+	t.l2.Delete(key)
+}
+
+// LoadAndDelete fetches key (checking L1 then L2) and removes it from
+// both tiers.
+func (t *TieredCache) LoadAndDelete(key any) (any, bool) {
+	value, ok := t.Get(key)
+	if !ok {
+		return nil, false
+	}
+	t.Delete(key)
+	return value, true
+}
+
+// Close closes both tiers, joining any errors.
+func (t *TieredCache) Close() error {
+	return errors.Join(t.l1.Close(), t.l2.Close())
+}